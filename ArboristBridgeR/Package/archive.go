@@ -0,0 +1,147 @@
+package main
+
+import (
+       "archive/tar"
+       "bufio"
+       "compress/gzip"
+       "fmt"
+       "io"
+       "os"
+       "path/filepath"
+       "strconv"
+       "strings"
+       "time"
+)
+
+
+// ArchivePackage walks the assembled topDest tree and writes it as a
+// gzip-compressed tar to outPath. If the SOURCE_DATE_EPOCH environment
+// variable is set to an integer, every tar header's ModTime is pinned to it and the
+// uid/gid/uname/gname fields are zeroed, so the resulting archive is
+// reproducible across machines and build times.
+//
+// When dryRun is true, topDest was never actually assembled on disk, so
+// ArchivePackage only prints the archive it would have written.
+//
+func ArchivePackage(topDest, outPath string, dryRun bool) error {
+  if dryRun {
+    fmt.Printf("ARCHIVE %s\n", outPath)
+    return nil
+  }
+
+  out, err := os.Create(outPath)
+  if err != nil {
+    return err
+  }
+  defer out.Close()
+
+  gzw := gzip.NewWriter(out)
+  tw := tar.NewWriter(gzw)
+
+  epoch, pinned := sourceDateEpoch()
+
+  err = filepath.Walk(topDest, func(path string, info os.FileInfo, err error) error {
+    if err != nil {
+      return err
+    }
+
+    relPath, err := filepath.Rel(topDest, path)
+    if err != nil {
+      return err
+    }
+    if relPath == "." {
+      return nil
+    }
+
+    var linkTarget string
+    isSymlink := info.Mode()&os.ModeSymlink != 0
+    if isSymlink {
+      linkTarget, err = os.Readlink(path)
+      if err != nil {
+        return err
+      }
+    }
+
+    header, err := tar.FileInfoHeader(info, linkTarget)
+    if err != nil {
+      return err
+    }
+    header.Name = filepath.ToSlash(filepath.Join(filepath.Base(topDest), relPath))
+
+    if pinned {
+      header.ModTime = epoch
+      header.AccessTime = epoch
+      header.ChangeTime = epoch
+      header.Uid = 0
+      header.Gid = 0
+      header.Uname = ""
+      header.Gname = ""
+    }
+
+    if err := tw.WriteHeader(header); err != nil {
+      return err
+    }
+
+    if info.IsDir() || isSymlink {
+      return nil
+    }
+
+    f, err := os.Open(path)
+    if err != nil {
+      return err
+    }
+    defer f.Close()
+
+    _, err = io.Copy(tw, f)
+    return err
+  })
+  if err != nil {
+    return err
+  }
+
+  if err := tw.Close(); err != nil {
+    return err
+  }
+  return gzw.Close()
+}
+
+
+// sourceDateEpoch reports the pinned mtime requested via the
+// SOURCE_DATE_EPOCH environment variable, and whether it was set to a
+// valid integer; an unset or unparsable value reports false.
+//
+func sourceDateEpoch() (time.Time, bool) {
+  raw := os.Getenv("SOURCE_DATE_EPOCH")
+  if raw == "" {
+    return time.Time{}, false
+  }
+
+  secs, err := strconv.ParseInt(raw, 10, 64)
+  if err != nil {
+    return time.Time{}, false
+  }
+  return time.Unix(secs, 0).UTC(), true
+}
+
+
+// packageVersion reads the Version: line out of a copied DESCRIPTION file.
+//
+func packageVersion(descriptionPath string) (string, error) {
+  f, err := os.Open(descriptionPath)
+  if err != nil {
+    return "", err
+  }
+  defer f.Close()
+
+  scanner := bufio.NewScanner(f)
+  for scanner.Scan() {
+    line := scanner.Text()
+    if strings.HasPrefix(line, "Version:") {
+      return strings.TrimSpace(strings.TrimPrefix(line, "Version:")), nil
+    }
+  }
+  if err := scanner.Err(); err != nil {
+    return "", err
+  }
+  return "", fmt.Errorf("%s: no Version: line found", descriptionPath)
+}
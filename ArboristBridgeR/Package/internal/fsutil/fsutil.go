@@ -0,0 +1,167 @@
+// Package fsutil collects the file-copying primitives shared by the
+// package builders (the CRAN and Python sdist layouts). They are
+// adapted from Github project termie/go-shutil, hardened to fsync and
+// chmod destinations, reproduce rather than dereference symlinks, and
+// write atomically.
+package fsutil
+
+import (
+       "crypto/sha256"
+       "encoding/hex"
+       "fmt"
+       "io"
+       "os"
+       "path/filepath"
+)
+
+
+// CopyFile copies data from src to dst.
+//
+// If src is a symlink, the link itself is reproduced at dst via
+// os.Readlink/os.Symlink rather than following it and copying the
+// target's bytes into a new regular file -- set followSymlinks to
+// restore the old dereferencing behavior.
+//
+// A regular file's destination is written to dst+".tmp" and then renamed
+// into place, so a process interrupted mid-copy never leaves a partial
+// file at dst. A reproduced symlink replaces dst directly.
+//
+// When dryRun is true, nothing on disk is touched; the action that would
+// have been taken is printed instead.
+//
+func CopyFile(src, dst string, followSymlinks, dryRun bool) error {
+  srcInfo, err := os.Lstat(src)
+  if err != nil {
+    return err
+  }
+
+  if srcInfo.Mode()&os.ModeSymlink != 0 && !followSymlinks {
+    target, err := os.Readlink(src)
+    if err != nil {
+      return err
+    }
+    if dryRun {
+      fmt.Printf("SYMLINK %s -> %s\n", target, dst)
+      return nil
+    }
+    os.Remove(dst)
+    return os.Symlink(target, dst)
+  }
+
+  srcInfo, err = os.Stat(src)
+  if err != nil {
+    return err
+  }
+
+  if dryRun {
+    fmt.Printf("COPY %s -> %s\n", src, dst)
+    return nil
+  }
+
+  fsrc, err := os.Open(src)
+  if err != nil {
+    return err
+  }
+  defer fsrc.Close()
+
+  tmpDst := dst + ".tmp"
+  fdst, err := os.Create(tmpDst)
+  if err != nil {
+    return err
+  }
+
+  if _, err := io.Copy(fdst, fsrc); err != nil {
+    fdst.Close()
+    os.Remove(tmpDst)
+    return err
+  }
+
+  if err := fdst.Sync(); err != nil {
+    fdst.Close()
+    os.Remove(tmpDst)
+    return err
+  }
+
+  if err := fdst.Close(); err != nil {
+    os.Remove(tmpDst)
+    return err
+  }
+
+  if err := os.Chmod(tmpDst, srcInfo.Mode()); err != nil {
+    os.Remove(tmpDst)
+    return err
+  }
+
+  return os.Rename(tmpDst, dst)
+}
+
+
+// CopyGlob expands pattern with filepath.Glob and copies every match
+// whose base name doesn't match an ignore pattern into the dst
+// directory, returning the (src, dst) pairs copied (in dry-run mode, the
+// pairs that would have been copied) so callers can record them (e.g. in
+// a build manifest).
+//
+func CopyGlob(pattern, dst string, followSymlinks, dryRun bool, ignore []string) (copied [][2]string, err error) {
+  matches, err := filepath.Glob(pattern)
+  if err != nil {
+    return nil, err
+  }
+
+  for _, match := range matches {
+    if ShouldIgnore(filepath.Base(match), ignore) {
+      continue
+    }
+    dstPath := filepath.Join(dst, filepath.Base(match))
+    if err := CopyFile(match, dstPath, followSymlinks, dryRun); err != nil {
+      return nil, err
+    }
+    copied = append(copied, [2]string{match, dstPath})
+  }
+  return copied, nil
+}
+
+
+// ShouldIgnore reports whether name matches any of the given glob
+// patterns (mirroring the "ignore" callable from Python's
+// shutil.copytree).
+//
+func ShouldIgnore(name string, ignore []string) bool {
+  for _, pattern := range ignore {
+    if matched, err := filepath.Match(pattern, name); err == nil && matched {
+      return true
+    }
+  }
+  return false
+}
+
+
+// MkdirAll creates path with the given mode, or -- when dryRun is true --
+// prints the directory that would have been created.
+//
+func MkdirAll(path string, mode os.FileMode, dryRun bool) error {
+  if dryRun {
+    fmt.Printf("MKDIR %s\n", path)
+    return nil
+  }
+  return os.MkdirAll(path, mode)
+}
+
+
+// HashFile reports the size and hex-encoded SHA-256 digest of the file at
+// path, for manifest entries recorded after a real (non-dry-run) copy.
+//
+func HashFile(path string) (size int64, sum string, err error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return 0, "", err
+  }
+  defer f.Close()
+
+  h := sha256.New()
+  n, err := io.Copy(h, f)
+  if err != nil {
+    return 0, "", err
+  }
+  return n, hex.EncodeToString(h.Sum(nil)), nil
+}
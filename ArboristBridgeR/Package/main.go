@@ -0,0 +1,86 @@
+//  arborist-package assembles a distributable package tree for one of
+//  Arborist's front ends, then archives it as a tar.gz (reproducible when
+//  SOURCE_DATE_EPOCH is set) and, unless -keep-tree or -n is given,
+//  deletes the source tree.
+//
+//  Usage:
+//      arborist-package r    builds the CRAN Rborist package (default)
+//      arborist-package py   builds the pyborist Python sdist
+
+package main
+
+import (
+       "context"
+       "flag"
+       "fmt"
+       "os"
+       "path/filepath"
+       "runtime"
+       "strings"
+)
+
+
+func main() {
+  flavor := "r"
+  args := os.Args[1:]
+  if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+    flavor = args[0]
+    args = args[1:]
+  }
+
+  var p Packager
+  switch flavor {
+  case "r":
+    p = RboristCRAN{}
+  case "py":
+    p = PyboristSdist{}
+  default:
+    fmt.Printf("unknown package flavor %q (want \"r\" or \"py\")\n", flavor)
+    os.Exit(1)
+  }
+
+  flagSet := flag.NewFlagSet("arborist-package "+flavor, flag.ExitOnError)
+  keepTree := flagSet.Bool("keep-tree", false, "keep the assembled tree after archiving")
+  followSymlinks := flagSet.Bool("follow-symlinks", false, "dereference symlinks instead of reproducing them")
+  jobs := flagSet.Int("j", runtime.NumCPU(), "number of concurrent file copies in CopyTree")
+  verbose := flagSet.Bool("v", false, "print progress during recursive tree copies")
+  dryRun := flagSet.Bool("n", false, "print what would be done without touching disk")
+  flagSet.BoolVar(dryRun, "dry-run", false, "alias for -n")
+  flagSet.Parse(args)
+
+  ignore, err := loadIgnorePatterns(".." + string(filepath.Separator) + ".Rbuildignore")
+  if err != nil {
+    fmt.Printf("Cannot load .Rbuildignore: %v", err)
+    return
+  }
+
+  mf, err := Assemble(context.Background(), p, ignore, *followSymlinks, *jobs, *dryRun, *verbose)
+  if err != nil {
+    fmt.Printf("Cannot assemble %s: %v", p.TopDest(), err)
+    return
+  }
+
+  version, err := p.Version()
+  if err != nil {
+    fmt.Printf("Cannot determine package version: %v", err)
+    return
+  }
+
+  archiveName := p.ArchiveName(version)
+  if err := ArchivePackage(p.TopDest(), archiveName, *dryRun); err != nil {
+    fmt.Printf("Cannot write %s: %v", archiveName, err)
+    return
+  }
+
+  manifestPath := archiveName + ".manifest.json"
+  if *dryRun {
+    fmt.Printf("WRITE %s\n", manifestPath)
+  } else if err := mf.writeTo(manifestPath); err != nil {
+    fmt.Printf("Cannot write %s: %v", manifestPath, err)
+    return
+  }
+
+  if !*keepTree && !*dryRun {
+    os.RemoveAll(p.TopDest())
+  }
+}
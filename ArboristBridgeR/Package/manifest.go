@@ -0,0 +1,87 @@
+package main
+
+import (
+       "crypto/sha256"
+       "encoding/hex"
+       "encoding/json"
+       "io/ioutil"
+       "os"
+       "sync"
+
+       "suiji/Arborist/ArboristBridgeR/Package/internal/fsutil"
+)
+
+
+// ManifestEntry describes one file copied into the assembled tree.
+type ManifestEntry struct {
+  Source string `json:"source"`
+  Dest   string `json:"dest"`
+  Size   int64  `json:"size"`
+  SHA256 string `json:"sha256"`
+}
+
+
+// manifest accumulates ManifestEntry records across the serial and
+// worker-pool copy stages; its methods are safe for concurrent use.
+type manifest struct {
+  mu      sync.Mutex
+  entries []ManifestEntry
+}
+
+
+// record stats and hashes the already-copied file at dst and appends an
+// entry for it. It is a no-op in dry-run mode, since nothing was written.
+//
+// dst may itself be a symlink (fsutil.CopyFile reproduces rather than
+// follows them); opening it would follow the link, which can race
+// against its target's own copy, or resolve outside the assembled tree
+// entirely. For a symlink, the digest is taken over the link target
+// text instead of its content.
+//
+func (m *manifest) record(src, dst string, dryRun bool) error {
+  if dryRun {
+    return nil
+  }
+
+  info, err := os.Lstat(dst)
+  if err != nil {
+    return err
+  }
+
+  var size int64
+  var sum string
+  if info.Mode()&os.ModeSymlink != 0 {
+    target, err := os.Readlink(dst)
+    if err != nil {
+      return err
+    }
+    size = int64(len(target))
+    digest := sha256.Sum256([]byte(target))
+    sum = hex.EncodeToString(digest[:])
+  } else {
+    size, sum, err = fsutil.HashFile(dst)
+    if err != nil {
+      return err
+    }
+  }
+
+  m.mu.Lock()
+  m.entries = append(m.entries, ManifestEntry{Source: src, Dest: dst, Size: size, SHA256: sum})
+  m.mu.Unlock()
+  return nil
+}
+
+
+// writeTo marshals the accumulated entries as indented JSON to path.
+//
+func (m *manifest) writeTo(path string) error {
+  m.mu.Lock()
+  entries := m.entries
+  m.mu.Unlock()
+
+  data, err := json.MarshalIndent(entries, "", "  ")
+  if err != nil {
+    return err
+  }
+  return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+       "context"
+       "fmt"
+       "io/ioutil"
+       "os"
+       "path/filepath"
+
+       "suiji/Arborist/ArboristBridgeR/Package/internal/fsutil"
+)
+
+
+// LayoutEntry maps one source-glob (or, when Recursive, one source
+// directory) to a destination subdirectory of the assembled tree.
+type LayoutEntry struct {
+  SourceGlob string
+  DestSubdir string // relative to the tree's top directory; "" for the top level itself
+  Recursive  bool   // SourceGlob names a directory to deep-copy via CopyTree instead of CopyGlob
+}
+
+
+// Packager describes one flavor of distributable package tree -- CRAN's
+// Rborist layout or a Python sdist -- so the copy/archive
+// plumbing in Assemble and ArchivePackage can be shared across front
+// ends instead of duplicated per flavor.
+type Packager interface {
+  // TopDest is the directory the package tree is assembled under.
+  TopDest() string
+
+  // Layout lists the glob/directory to destination-subdirectory
+  // mappings copied verbatim into the tree.
+  Layout() []LayoutEntry
+
+  // WriteMetadata writes any metadata files Layout does not cover --
+  // content that is generated or transformed rather than copied
+  // verbatim (setup.py, pyproject.toml; the R layout has none). Writes
+  // are recorded in mf the same way Layout copies are.
+  WriteMetadata(topDest string, dryRun bool, mf *manifest) error
+
+  // Version reports the package version, used for the archive name.
+  Version() (string, error)
+
+  // ArchiveName returns the output tar.gz name for the given version.
+  ArchiveName(version string) string
+}
+
+
+// Assemble builds p's package tree: it creates TopDest, copies every
+// Layout entry, then calls WriteMetadata. The returned manifest records
+// every file copied or written, ready for manifest.writeTo; in dry-run
+// mode it is empty.
+//
+func Assemble(ctx context.Context, p Packager, ignore []string, followSymlinks bool, workers int, dryRun, verbose bool) (*manifest, error) {
+  srcFileInfo, err := os.Stat(".")
+  if err != nil {
+    return nil, err
+  }
+
+  topDest := p.TopDest()
+
+  if _, err := os.Open(topDest); !os.IsNotExist(err) {
+    return nil, fmt.Errorf("%s already exists", topDest)
+  }
+
+  if err := fsutil.MkdirAll(topDest, srcFileInfo.Mode(), dryRun); err != nil {
+    return nil, err
+  }
+
+  mf := &manifest{}
+
+  for _, entry := range p.Layout() {
+    dst := topDest
+    if entry.DestSubdir != "" {
+      dst = filepath.Join(topDest, entry.DestSubdir)
+    }
+
+    if entry.Recursive {
+      if err := CopyTree(ctx, entry.SourceGlob, dst, ignore, followSymlinks, workers, dryRun, verbose, mf); err != nil {
+        return nil, err
+      }
+      continue
+    }
+
+    if err := fsutil.MkdirAll(dst, srcFileInfo.Mode(), dryRun); err != nil {
+      return nil, err
+    }
+
+    copied, err := fsutil.CopyGlob(entry.SourceGlob, dst, followSymlinks, dryRun, ignore)
+    if err != nil {
+      return nil, err
+    }
+    for _, pair := range copied {
+      if err := mf.record(pair[0], pair[1], dryRun); err != nil {
+        return nil, err
+      }
+    }
+  }
+
+  if err := p.WriteMetadata(topDest, dryRun, mf); err != nil {
+    return nil, err
+  }
+
+  return mf, nil
+}
+
+
+// writeGeneratedFile writes content to dst (0644), or -- in dry-run mode
+// -- just prints the write that would have happened. A manifest entry
+// with an empty Source records that the file was generated rather than
+// copied from the source tree.
+//
+func writeGeneratedFile(dst, content string, dryRun bool, mf *manifest) error {
+  if dryRun {
+    fmt.Printf("WRITE %s\n", dst)
+    return nil
+  }
+
+  if err := ioutil.WriteFile(dst, []byte(content), 0644); err != nil {
+    return err
+  }
+  return mf.record("", dst, false)
+}
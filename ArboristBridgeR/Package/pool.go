@@ -0,0 +1,148 @@
+package main
+
+import (
+       "context"
+       "fmt"
+       "io/ioutil"
+       "os"
+       "path/filepath"
+       "sync"
+
+       "suiji/Arborist/ArboristBridgeR/Package/internal/fsutil"
+)
+
+
+// copyJob is a single regular-file copy enumerated by enumerateCopyJobs.
+type copyJob struct {
+  src, dst string
+}
+
+
+// enumerateCopyJobs walks src, creating the mirrored directory structure
+// under dst serially (to preserve ordering), and returns a job per
+// regular file or symlink found. Entries matching ignore are skipped.
+//
+func enumerateCopyJobs(src, dst string, ignore []string, dryRun bool) ([]copyJob, error) {
+  srcFileInfo, err := os.Stat(src)
+  if err != nil {
+    return nil, err
+  }
+
+  if err := fsutil.MkdirAll(dst, srcFileInfo.Mode(), dryRun); err != nil {
+    return nil, err
+  }
+
+  entries, err := ioutil.ReadDir(src)
+  if err != nil {
+    return nil, err
+  }
+
+  var jobs []copyJob
+  for _, entry := range entries {
+    if fsutil.ShouldIgnore(entry.Name(), ignore) {
+      continue
+    }
+
+    srcPath := filepath.Join(src, entry.Name())
+    dstPath := filepath.Join(dst, entry.Name())
+
+    entryFileInfo, err := os.Lstat(srcPath)
+    if err != nil {
+      return nil, err
+    }
+
+    if entryFileInfo.IsDir() {
+      sub, err := enumerateCopyJobs(srcPath, dstPath, ignore, dryRun)
+      if err != nil {
+        return nil, err
+      }
+      jobs = append(jobs, sub...)
+    } else {
+      jobs = append(jobs, copyJob{src: srcPath, dst: dstPath})
+    }
+  }
+  return jobs, nil
+}
+
+
+// runCopyPool drains jobs across a bounded pool of workers, copying each
+// with fsutil.CopyFile. The first worker error cancels ctx so the rest
+// stop early, and that first error is what runCopyPool returns. When
+// verbose is true, a progress line is printed every 100 files copied.
+// Successful, non-dry-run copies are recorded in mf.
+//
+func runCopyPool(ctx context.Context, jobs []copyJob, workers int, followSymlinks, dryRun, verbose bool, mf *manifest) error {
+  if workers < 1 {
+    workers = 1
+  }
+
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  jobCh := make(chan copyJob)
+  var wg sync.WaitGroup
+
+  var errOnce sync.Once
+  var firstErr error
+
+  var mu sync.Mutex
+  var done int
+
+  for i := 0; i < workers; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for {
+        select {
+        case <-ctx.Done():
+          return
+        case job, ok := <-jobCh:
+          if !ok {
+            return
+          }
+          if err := fsutil.CopyFile(job.src, job.dst, followSymlinks, dryRun); err != nil {
+            errOnce.Do(func() {
+              firstErr = err
+              cancel()
+            })
+            return
+          }
+          if err := mf.record(job.src, job.dst, dryRun); err != nil {
+            errOnce.Do(func() {
+              firstErr = err
+              cancel()
+            })
+            return
+          }
+          if verbose {
+            mu.Lock()
+            done++
+            n := done
+            mu.Unlock()
+            if n%100 == 0 {
+              fmt.Printf("copied %d/%d files\n", n, len(jobs))
+            }
+          }
+        }
+      }
+    }()
+  }
+
+feed:
+  for _, job := range jobs {
+    select {
+    case jobCh <- job:
+    case <-ctx.Done():
+      break feed
+    }
+  }
+  close(jobCh)
+
+  wg.Wait()
+
+  if verbose && firstErr == nil {
+    fmt.Printf("copied %d/%d files\n", len(jobs), len(jobs))
+  }
+
+  return firstErr
+}
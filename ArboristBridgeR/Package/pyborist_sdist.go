@@ -0,0 +1,85 @@
+package main
+
+import (
+       "fmt"
+       "os"
+       "path/filepath"
+)
+
+
+// PyboristSdist lays out a Python source distribution: the C++ core
+// under pyborist/_core/, the FrontEnd/python sources (if present) under
+// pyborist/, and a generated setup.py/pyproject.toml pair in place of
+// the DESCRIPTION/NAMESPACE metadata the R layout copies verbatim.
+type PyboristSdist struct{}
+
+
+func (PyboristSdist) TopDest() string { return "pyborist" }
+
+
+func (PyboristSdist) Layout() []LayoutEntry {
+  sep := string(filepath.Separator)
+  coreSource := ".." + sep + "ArboristCore" + sep
+  sharedSource := ".." + sep + "Shared" + sep
+
+  layout := []LayoutEntry{
+    {SourceGlob: coreSource + "*", DestSubdir: "_core"},
+    {SourceGlob: sharedSource + "*", DestSubdir: "_core"},
+  }
+
+  pySource := ".." + sep + "FrontEnd" + sep + "python"
+  if info, err := os.Stat(pySource); err == nil && info.IsDir() {
+    layout = append(layout, LayoutEntry{SourceGlob: pySource, DestSubdir: "", Recursive: true})
+  }
+
+  return layout
+}
+
+
+func (p PyboristSdist) WriteMetadata(topDest string, dryRun bool, mf *manifest) error {
+  version, err := p.Version()
+  if err != nil {
+    return err
+  }
+
+  if err := writeGeneratedFile(filepath.Join(topDest, "setup.py"), pySetupPy(version), dryRun, mf); err != nil {
+    return err
+  }
+  return writeGeneratedFile(filepath.Join(topDest, "pyproject.toml"), pyProjectToml(version), dryRun, mf)
+}
+
+
+func (PyboristSdist) Version() (string, error) {
+  sep := string(filepath.Separator)
+  return packageVersion(".." + sep + "FrontEnd" + sep + "DESCRIPTION")
+}
+
+
+func (PyboristSdist) ArchiveName(version string) string {
+  return "pyborist-" + version + ".tar.gz"
+}
+
+
+func pySetupPy(version string) string {
+  return fmt.Sprintf(`from setuptools import setup, find_packages
+
+setup(
+    name="pyborist",
+    version=%q,
+    packages=find_packages(),
+    include_package_data=True,
+)
+`, version)
+}
+
+
+func pyProjectToml(version string) string {
+  return fmt.Sprintf(`[build-system]
+requires = ["setuptools>=61.0"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = "pyborist"
+version = %q
+`, version)
+}
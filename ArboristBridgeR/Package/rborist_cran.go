@@ -0,0 +1,51 @@
+package main
+
+import (
+       "path/filepath"
+)
+
+
+// RboristCRAN is the original CRAN layout: DESCRIPTION/NAMESPACE/LICENSE
+// at the top, man pages under man/, R sources under R/, and the C++
+// core under src/, plus a deep copy of the test corpus.
+type RboristCRAN struct{}
+
+
+func (RboristCRAN) TopDest() string { return "Rborist" }
+
+
+func (RboristCRAN) Layout() []LayoutEntry {
+  sep := string(filepath.Separator)
+  feSource := ".." + sep + "FrontEnd" + sep
+  coreSource := ".." + sep + "ArboristCore" + sep
+  sharedSource := ".." + sep + "Shared" + sep
+
+  return []LayoutEntry{
+    {SourceGlob: ".." + sep + "LICENSE"},
+    {SourceGlob: feSource + "DESCRIPTION"},
+    {SourceGlob: feSource + "NAMESPACE"},
+    {SourceGlob: feSource + "*.Rd", DestSubdir: "man"},
+    {SourceGlob: feSource + "*R", DestSubdir: "R"},
+    {SourceGlob: coreSource + "*", DestSubdir: "src"},
+    {SourceGlob: sharedSource + "*", DestSubdir: "src"},
+    {SourceGlob: ".." + sep + "tests", Recursive: true},
+  }
+}
+
+
+// WriteMetadata is a no-op: DESCRIPTION and NAMESPACE are copied
+// verbatim by Layout, rather than generated.
+func (RboristCRAN) WriteMetadata(topDest string, dryRun bool, mf *manifest) error {
+  return nil
+}
+
+
+func (RboristCRAN) Version() (string, error) {
+  sep := string(filepath.Separator)
+  return packageVersion(".." + sep + "FrontEnd" + sep + "DESCRIPTION")
+}
+
+
+func (RboristCRAN) ArchiveName(version string) string {
+  return "Rborist_" + version + ".tar.gz"
+}
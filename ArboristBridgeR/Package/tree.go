@@ -0,0 +1,59 @@
+package main
+
+import (
+       "bufio"
+       "context"
+       "os"
+       "strings"
+)
+
+
+// loadIgnorePatterns reads a .Rbuildignore-style file of newline-separated
+// glob patterns, one per line, blank lines and "#"-comments skipped. It is
+// not an error for path to be missing; an empty ignore list is returned.
+//
+func loadIgnorePatterns(path string) ([]string, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    if os.IsNotExist(err) {
+      return nil, nil
+    }
+    return nil, err
+  }
+  defer f.Close()
+
+  var patterns []string
+  scanner := bufio.NewScanner(f)
+  for scanner.Scan() {
+    line := strings.TrimSpace(scanner.Text())
+    if line == "" || strings.HasPrefix(line, "#") {
+      continue
+    }
+    patterns = append(patterns, line)
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, err
+  }
+  return patterns, nil
+}
+
+
+// Recursively copy a directory tree.
+//
+// The destination directory is created if missing; if it already exists,
+// the copied entries are added to it. ignore is a list of
+// glob patterns (mirroring the "ignore" callable from Python's
+// shutil.copytree) matched against each entry's base name and skipped.
+//
+// Directories are created serially, walking src once to preserve
+// ordering; the file and symlink copies that walk enumerates are then
+// drained by a bounded pool of workers. The first worker error cancels
+// ctx and is returned.
+//
+func CopyTree(ctx context.Context, src, dst string, ignore []string, followSymlinks bool, workers int, dryRun, verbose bool, mf *manifest) error {
+  jobs, err := enumerateCopyJobs(src, dst, ignore, dryRun)
+  if err != nil {
+    return err
+  }
+  return runCopyPool(ctx, jobs, workers, followSymlinks, dryRun, verbose, mf)
+}